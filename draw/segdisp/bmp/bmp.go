@@ -0,0 +1,89 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bmp implements a minimal BMP encoder.
+//
+// The standard library doesn't ship an encoder for the BMP format (only a
+// decoder in image/bmp), so this package provides just enough of one to let
+// the segdisp packages export rendered images without an external
+// dependency. It always writes an uncompressed 32 bits-per-pixel BGRA
+// bitmap. Each channel holds the high 8 bits of the alpha-premultiplied
+// value returned by color.Color.RGBA, so images with more than 8 bits per
+// channel or with non-premultiplied partial alpha lose precision.
+package bmp
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"image"
+	"io"
+)
+
+const (
+	fileHeaderLen = 14
+	infoHeaderLen = 40
+)
+
+// Encode writes the image m to w in BMP format.
+func Encode(w io.Writer, m image.Image) error {
+	b := m.Bounds()
+	width, height := b.Dx(), b.Dy()
+	if width <= 0 || height <= 0 {
+		return fmt.Errorf("invalid image bounds %v, both dimensions must be positive", b)
+	}
+
+	bw := bufio.NewWriter(w)
+
+	pixelDataLen := width * height * 4
+	fileSize := fileHeaderLen + infoHeaderLen + pixelDataLen
+
+	// Bitmap file header.
+	var fileHeader [fileHeaderLen]byte
+	fileHeader[0] = 'B'
+	fileHeader[1] = 'M'
+	binary.LittleEndian.PutUint32(fileHeader[2:6], uint32(fileSize))
+	binary.LittleEndian.PutUint32(fileHeader[10:14], fileHeaderLen+infoHeaderLen)
+	if _, err := bw.Write(fileHeader[:]); err != nil {
+		return fmt.Errorf("unable to write the BMP file header: %v", err)
+	}
+
+	// BITMAPINFOHEADER, stores rows bottom-up with no compression.
+	var infoHeader [infoHeaderLen]byte
+	binary.LittleEndian.PutUint32(infoHeader[0:4], infoHeaderLen)
+	binary.LittleEndian.PutUint32(infoHeader[4:8], uint32(width))
+	binary.LittleEndian.PutUint32(infoHeader[8:12], uint32(height))
+	binary.LittleEndian.PutUint16(infoHeader[12:14], 1)  // Color planes.
+	binary.LittleEndian.PutUint16(infoHeader[14:16], 32) // Bits per pixel.
+	binary.LittleEndian.PutUint32(infoHeader[20:24], uint32(pixelDataLen))
+	if _, err := bw.Write(infoHeader[:]); err != nil {
+		return fmt.Errorf("unable to write the BMP info header: %v", err)
+	}
+
+	// Pixel data is stored bottom-up, left-to-right, as BGRA quads.
+	var row [4]byte
+	for y := b.Max.Y - 1; y >= b.Min.Y; y-- {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r, g, bl, a := m.At(x, y).RGBA()
+			row[0] = byte(bl >> 8)
+			row[1] = byte(g >> 8)
+			row[2] = byte(r >> 8)
+			row[3] = byte(a >> 8)
+			if _, err := bw.Write(row[:]); err != nil {
+				return fmt.Errorf("unable to write pixel data at (%d,%d): %v", x, y, err)
+			}
+		}
+	}
+	return bw.Flush()
+}
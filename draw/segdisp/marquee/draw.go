@@ -0,0 +1,180 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package marquee
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"time"
+
+	"github.com/mum4k/termdash/area"
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/draw/segdisp/sixteen"
+)
+
+// Draw draws the current state of the strip onto the canvas, advancing
+// the scroll and blink animation clocks by the time elapsed since the
+// previous call. Safe to call repeatedly from a widget's redraw loop, e.g.
+// once per frame.
+func (m *Marquee) Draw(cvs *canvas.Canvas) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.advance()
+
+	slots, err := m.slotAreas(cvs.Area())
+	if err != nil {
+		return fmt.Errorf("slotAreas => %v", err)
+	}
+
+	visible := m.visibleGlyphs()
+	for i, slot := range slots {
+		g := visible[i]
+		if g == nil || g.coverage <= 0 || (m.blinkRunes[g.idx] && !m.blinkVisible) {
+			continue
+		}
+
+		sub, err := cvs.Subcanvas(slot)
+		if err != nil {
+			return fmt.Errorf("slot %d: cvs.Subcanvas => %v", i, err)
+		}
+
+		d := sixteen.New(m.dispOpts...)
+		if err := d.SetCharacter(g.r); err != nil {
+			return fmt.Errorf("slot %d: SetCharacter(%q) => %v", i, g.r, err)
+		}
+		// Draw the glyph at its natural size and then mask off the
+		// fraction that is scrolled out of view, rather than shrinking
+		// the canvas handed to Draw (which would rescale the whole
+		// glyph instead of cropping it).
+		if err := d.Draw(sub); err != nil {
+			return fmt.Errorf("slot %d: Draw => %v", i, err)
+		}
+		if err := maskGlyphEdge(cvs, slot, m.dir, g.coverage); err != nil {
+			return fmt.Errorf("slot %d: maskGlyphEdge => %v", i, err)
+		}
+	}
+	return nil
+}
+
+// advance moves the scroll offset and flips the blink visibility
+// according to how much wall-clock time has passed since the last call.
+func (m *Marquee) advance() {
+	now := time.Now()
+	if m.lastAdvance.IsZero() {
+		m.lastAdvance = now
+		return
+	}
+	elapsed := now.Sub(m.lastAdvance)
+	m.lastAdvance = now
+
+	if m.speed > 0 && len(m.text) > 0 {
+		m.offset += m.speed * elapsed.Seconds()
+		for m.offset >= float64(len(m.text)) {
+			m.offset -= float64(len(m.text))
+		}
+	}
+
+	if m.blinkPeriod > 0 {
+		// Accumulate elapsed time across calls rather than consuming only
+		// the current one, so sub-period elapsed durations (the expected
+		// case when redrawing faster than blinkPeriod) still add up to a
+		// flip instead of being discarded every time.
+		m.blinkAccum += elapsed
+		for m.blinkAccum >= m.blinkPeriod {
+			m.blinkAccum -= m.blinkPeriod
+			m.blinkVisible = !m.blinkVisible
+		}
+	}
+}
+
+// visibleGlyph is the glyph shown in one character slot, and how much of
+// it is currently scrolled into view.
+type visibleGlyph struct {
+	r   rune
+	idx int
+	// coverage is the fraction (0, 1] of the glyph's cell slot that is
+	// occupied by content, the remainder nearest the scroll direction is
+	// the clipped part of a glyph scrolling in or out of view.
+	coverage float64
+}
+
+// visibleGlyphs returns, for each of the m.chars slots, the glyph
+// currently scrolled into it. The slots are nil only when no text has been
+// written; otherwise the text wraps around, so a text shorter than the
+// strip is repeated across it rather than leaving the remaining slots
+// empty.
+func (m *Marquee) visibleGlyphs() []*visibleGlyph {
+	out := make([]*visibleGlyph, m.chars)
+	if len(m.text) == 0 {
+		return out
+	}
+
+	start := m.offset
+	if m.dir == Right {
+		start = -m.offset
+	}
+
+	for slot := 0; slot < m.chars; slot++ {
+		pos := start + float64(slot)
+		idx := int(math.Floor(pos))
+		frac := pos - math.Floor(pos)
+		idx %= len(m.text)
+		if idx < 0 {
+			idx += len(m.text)
+		}
+
+		out[slot] = &visibleGlyph{
+			r:        m.text[idx],
+			idx:      idx,
+			coverage: 1 - frac,
+		}
+	}
+	return out
+}
+
+// slotAreas returns the per-character-slot cell areas for cellArea,
+// reusing the previous frame's computation when cellArea hasn't changed.
+func (m *Marquee) slotAreas(cellArea image.Rectangle) ([]image.Rectangle, error) {
+	if cellArea == m.slotsFor && len(m.slots) == m.chars {
+		return m.slots, nil
+	}
+
+	slotW := cellArea.Dx() / m.chars
+	if slotW < sixteen.MinCols {
+		return nil, fmt.Errorf("cell area %v is too small to fit %d character(s), each needs at least %d columns, got %d per slot", cellArea, m.chars, sixteen.MinCols, slotW)
+	}
+
+	slots := make([]image.Rectangle, m.chars)
+	for i := range slots {
+		w := slotW
+		if i == m.chars-1 {
+			w = cellArea.Max.X - (cellArea.Min.X + i*slotW)
+		}
+		slot := image.Rect(cellArea.Min.X+i*slotW, cellArea.Min.Y, cellArea.Min.X+i*slotW+w, cellArea.Max.Y)
+		slot = area.WithRatio(slot, slotAspectRatio)
+
+		required, err := sixteen.Required(slot)
+		if err != nil {
+			return nil, fmt.Errorf("slot %d: sixteen.Required => %v", i, err)
+		}
+		slots[i] = required
+	}
+
+	m.slotsFor = cellArea
+	m.slots = slots
+	return slots, nil
+}
@@ -0,0 +1,200 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package marquee composes multiple sixteen.Display instances into a
+// virtual N-character strip that can scroll or blink its content, turning
+// the static sixteen.Display into something usable for stock tickers, log
+// tails and status banners.
+package marquee
+
+import (
+	"fmt"
+	"image"
+	"sync"
+	"time"
+
+	"github.com/mum4k/termdash/draw/segdisp/sixteen"
+)
+
+// slotAspectRatio is the aspect ratio a character slot is reduced to
+// before sixteen.Required is consulted, mirroring sixteen.SetString so
+// that leftover cell columns remain available rather than being swallowed
+// by sixteen.Required's own aspect ratio adjustment.
+var slotAspectRatio = image.Point{sixteen.MinCols, sixteen.MinRows}
+
+// Marquee composes chars sixteen.Display instances into a single strip
+// and animates the text written to it.
+// This object is thread-safe.
+type Marquee struct {
+	mu sync.Mutex
+
+	// chars is the number of character slots the strip displays at once.
+	chars int
+	// dispOpts are the options applied to every underlying sixteen.Display.
+	dispOpts []sixteen.Option
+
+	// text is the full string currently being displayed or scrolled.
+	text []rune
+	// dir and speed configure an in-progress Scroll, speed is in glyphs
+	// per second. A zero speed means the strip is static.
+	dir   Direction
+	speed float64
+	// offset is the current scroll position, in fractional glyphs, into
+	// text. Advances by speed*elapsedSeconds on every Draw.
+	offset float64
+
+	// blinkRunes holds the indices into text that should blink.
+	blinkRunes  map[int]bool
+	blinkPeriod time.Duration
+	// blinkVisible is whether blinking runes are currently shown or
+	// hidden; it flips every blinkPeriod.
+	blinkVisible bool
+	// blinkAccum is the elapsed time since blinkVisible last flipped that
+	// hasn't yet accumulated to a full blinkPeriod. Persists across Draw
+	// calls so blinking still advances correctly when callers redraw, as
+	// recommended, faster than blinkPeriod, i.e. with elapsed durations
+	// individually shorter than a full period.
+	blinkAccum time.Duration
+
+	// lastAdvance is the wall-clock time Draw last advanced the
+	// animation clock by. Zero until the first Draw.
+	lastAdvance time.Time
+
+	// slots caches the per-character-slot cell areas computed by
+	// Required, keyed by the cell area they were computed for, so they
+	// don't get recomputed on every frame.
+	slotsFor image.Rectangle
+	slots    []image.Rectangle
+}
+
+// Direction is the direction a Marquee scrolls in.
+type Direction int
+
+// String implements fmt.Stringer.
+func (d Direction) String() string {
+	switch d {
+	case Left:
+		return "Left"
+	case Right:
+		return "Right"
+	default:
+		return "DirectionUnknown"
+	}
+}
+
+const (
+	directionUnknown Direction = iota
+
+	// Left scrolls the text towards the start of the strip.
+	Left
+	// Right scrolls the text towards the end of the strip.
+	Right
+)
+
+// Option is used to provide options to New.
+type Option interface {
+	// set sets the provided option.
+	set(*Marquee)
+}
+
+// option implements Option.
+type option func(*Marquee)
+
+// set implements Option.set.
+func (o option) set(m *Marquee) {
+	o(m)
+}
+
+// DisplayOptions sets the sixteen.Option applied to every character slot
+// in the strip.
+func DisplayOptions(opts ...sixteen.Option) Option {
+	return option(func(m *Marquee) {
+		m.dispOpts = opts
+	})
+}
+
+// New returns a new Marquee that displays chars characters at a time.
+func New(chars int, opts ...Option) (*Marquee, error) {
+	if chars <= 0 {
+		return nil, fmt.Errorf("invalid number of characters %d, must be a positive number", chars)
+	}
+
+	m := &Marquee{
+		chars:      chars,
+		blinkRunes: map[int]bool{},
+	}
+	for _, opt := range opts {
+		opt.set(m)
+	}
+	return m, nil
+}
+
+// Write sets the text the Marquee displays, replacing whatever was written
+// before it. Resets any in-progress scroll back to its start, but leaves a
+// previously configured Blink() in place.
+// The display only supports a subset of ASCII characters, use
+// sixteen.SupportsChars() or sixteen.Sanitize() to ensure the text is
+// supported.
+func (m *Marquee) Write(s string) error {
+	if ok, bad := sixteen.SupportsChars(s); !ok {
+		return fmt.Errorf("text %q contains unsupported characters %v", s, bad)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.text = []rune(s)
+	m.offset = 0
+	m.lastAdvance = time.Time{}
+	return nil
+}
+
+// Scroll starts scrolling the text in the provided direction at the
+// provided speed, in glyphs per second. A speed of zero stops scrolling.
+func (m *Marquee) Scroll(dir Direction, speed float64) error {
+	if dir != Left && dir != Right {
+		return fmt.Errorf("invalid direction %v", dir)
+	}
+	if speed < 0 {
+		return fmt.Errorf("invalid speed %v, must not be negative", speed)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.dir = dir
+	m.speed = speed
+	return nil
+}
+
+// Blink marks the glyphs at the provided zero-based indices into the text
+// written via Write as blinking, alternating between visible and hidden
+// every period. An empty runes slice disables blinking.
+func (m *Marquee) Blink(runes []int, period time.Duration) error {
+	if period <= 0 && len(runes) > 0 {
+		return fmt.Errorf("invalid blink period %v, must be positive", period)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.blinkRunes = map[int]bool{}
+	for _, r := range runes {
+		m.blinkRunes[r] = true
+	}
+	m.blinkPeriod = period
+	m.blinkVisible = true
+	m.blinkAccum = 0
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package marquee
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestVisibleGlyphsRightScrollNegativePosition(t *testing.T) {
+	m := &Marquee{
+		chars:  1,
+		text:   []rune("ABC"),
+		dir:    Right,
+		offset: 0.3, // start = -0.3, pos for the only slot is -0.3.
+	}
+
+	got := m.visibleGlyphs()
+	if len(got) != 1 || got[0] == nil {
+		t.Fatalf("visibleGlyphs() => %v, want one non-nil glyph", got)
+	}
+
+	// pos = -0.3 should floor to index len(text)-1 ('C') with a coverage
+	// of 0.3, not truncate to index 0 ('A') with an out-of-range coverage.
+	if got[0].idx != 2 || got[0].r != 'C' {
+		t.Errorf("visibleGlyphs()[0] => idx %d rune %q, want idx 2 rune 'C'", got[0].idx, got[0].r)
+	}
+	if got[0].coverage < 0 || got[0].coverage > 1 {
+		t.Errorf("visibleGlyphs()[0].coverage => %v, want a value in [0,1]", got[0].coverage)
+	}
+}
+
+// TestAdvanceBlinkAccumulatesSubPeriodElapsed exercises the documented
+// usage pattern of redrawing faster than blinkPeriod, i.e. every
+// individual call to advance (via Draw) observes an elapsed duration
+// shorter than blinkPeriod. The blink must still flip once the elapsed
+// durations accumulate to a full period, rather than being discarded on
+// every call.
+func TestAdvanceBlinkAccumulatesSubPeriodElapsed(t *testing.T) {
+	const (
+		period = 1 * time.Second
+		step   = 200 * time.Millisecond
+		steps  = 5 // 5 * 200ms == one full period.
+	)
+
+	m := &Marquee{
+		chars:        1,
+		blinkPeriod:  period,
+		blinkVisible: true,
+	}
+
+	for i := 1; i <= steps; i++ {
+		// Backdate lastAdvance instead of sleeping, so each call to
+		// advance observes an elapsed duration of roughly step without
+		// the test taking a full second to run.
+		m.lastAdvance = time.Now().Add(-step)
+		m.advance()
+
+		if i < steps {
+			if !m.blinkVisible {
+				t.Fatalf("after step %d (%v elapsed): blinkVisible = false, want true (period not yet reached)", i, time.Duration(i)*step)
+			}
+		}
+	}
+
+	if m.blinkVisible {
+		t.Errorf("after %d steps of %v (%v total, period %v): blinkVisible = true, want false", steps, step, time.Duration(steps)*step, period)
+	}
+}
+
+func TestMaskedSubColumns(t *testing.T) {
+	tests := []struct {
+		name                 string
+		totalSub, visibleSub int
+		dir                  Direction
+		want                 []int
+	}{
+		{"left scroll masks trailing columns", 10, 7, Left, []int{7, 8, 9}},
+		{"right scroll masks leading columns", 10, 7, Right, []int{0, 1, 2}},
+		{"fully covered masks nothing", 10, 10, Left, nil},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got := maskedSubColumns(tc.totalSub, tc.visibleSub, tc.dir)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("maskedSubColumns(%d, %d, %v) => %v, want %v", tc.totalSub, tc.visibleSub, tc.dir, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package marquee
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/canvas/braille"
+)
+
+// Braille Unicode block (U+2800-U+28FF): one bit per dot, laid out as two
+// columns of four dots each. Dots 1, 2, 3 and 7 form the left column and
+// dots 4, 5, 6 and 8 form the right one.
+const (
+	brailleBase  = 0x2800
+	brailleLast  = 0x28FF
+	leftDotMask  = 0x01 | 0x02 | 0x04 | 0x40
+	rightDotMask = 0x08 | 0x10 | 0x20 | 0x80
+)
+
+// maskGlyphEdge clears whole braille dot-columns at the edge of slot that
+// fall outside the visible fraction (coverage) of a glyph scrolling into
+// or out of view, masking the leftmost or rightmost partial glyph at
+// braille sub-pixel (one of braille.ColMult columns per cell) rather than
+// whole-cell granularity, so scrolling reads as sub-character-smooth.
+// slot must already have been fully drawn with the glyph at its natural
+// size; this only clears dots, it never sets them.
+func maskGlyphEdge(cvs *canvas.Canvas, slot image.Rectangle, dir Direction, coverage float64) error {
+	if coverage >= 1 {
+		return nil
+	}
+
+	totalSub := slot.Dx() * braille.ColMult
+	visibleSub := int(float64(totalSub) * coverage)
+	if visibleSub < 0 {
+		visibleSub = 0
+	}
+	if visibleSub >= totalSub {
+		return nil
+	}
+
+	for _, s := range maskedSubColumns(totalSub, visibleSub, dir) {
+		x := slot.Min.X + s/braille.ColMult
+		col := s % braille.ColMult
+		for y := slot.Min.Y; y < slot.Max.Y; y++ {
+			if err := maskDotColumn(cvs, image.Point{x, y}, col); err != nil {
+				return fmt.Errorf("maskDotColumn(%v, %d) => %v", image.Point{x, y}, col, err)
+			}
+		}
+	}
+	return nil
+}
+
+// maskedSubColumns returns the indices, out of totalSub braille sub-columns
+// numbered left to right, that should be masked given visibleSub of them
+// are covered by content. For Right the leading (leftmost) sub-columns are
+// masked; otherwise (Left, the default) the trailing (rightmost) ones are.
+func maskedSubColumns(totalSub, visibleSub int, dir Direction) []int {
+	var masked []int
+	if dir == Right {
+		for s := 0; s < totalSub-visibleSub; s++ {
+			masked = append(masked, s)
+		}
+	} else {
+		for s := visibleSub; s < totalSub; s++ {
+			masked = append(masked, s)
+		}
+	}
+	return masked
+}
+
+// maskDotColumn clears the left (col == 0) or right (col == 1) braille dot
+// column of the cell at p, leaving the rest of the cell's dots and its
+// other options untouched. A no-op if the cell doesn't currently hold a
+// braille rune.
+func maskDotColumn(cvs *canvas.Canvas, p image.Point, col int) error {
+	c, err := cvs.Cell(p)
+	if err != nil {
+		return fmt.Errorf("cvs.Cell => %v", err)
+	}
+
+	if c.Rune < brailleBase || c.Rune > brailleLast {
+		return nil
+	}
+
+	mask := rune(leftDotMask)
+	if col == 1 {
+		mask = rightDotMask
+	}
+	newRune := brailleBase + ((c.Rune - brailleBase) &^ mask)
+	_, err = cvs.SetCell(p, newRune, c.Opts...)
+	return err
+}
@@ -0,0 +1,76 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package segdisp holds functionality shared by the segment display
+// implementations, e.g. sixteen.
+package segdisp
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+
+	"github.com/mum4k/termdash/draw/segdisp/bmp"
+)
+
+// ImageFormat identifies a raster format an image can be encoded to.
+type ImageFormat int
+
+// String implements fmt.Stringer.
+func (f ImageFormat) String() string {
+	if n, ok := imageFormatNames[f]; ok {
+		return n
+	}
+	return "ImageFormatUnknown"
+}
+
+// imageFormatNames maps ImageFormat values to human readable names.
+var imageFormatNames = map[ImageFormat]string{
+	PNG: "PNG",
+	BMP: "BMP",
+}
+
+const (
+	imageFormatUnknown ImageFormat = iota
+
+	// PNG identifies the Portable Network Graphics format.
+	PNG
+	// BMP identifies the Windows Bitmap format.
+	BMP
+)
+
+// EncodeImage encodes img in the requested format and writes it to w.
+// This allows any segment display that exposes a RenderImage method (e.g.
+// sixteen.Display) to be exported to a file, an HTTP response or any other
+// io.Writer without the caller having to know which packages (image/png or
+// the bundled bmp package) implement the encoders.
+func EncodeImage(w io.Writer, img image.Image, f ImageFormat) error {
+	switch f {
+	case PNG:
+		if err := png.Encode(w, img); err != nil {
+			return fmt.Errorf("png.Encode => %v", err)
+		}
+		return nil
+
+	case BMP:
+		if err := bmp.Encode(w, img); err != nil {
+			return fmt.Errorf("bmp.Encode => %v", err)
+		}
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported image format %v(%d)", f, f)
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segment
+
+import (
+	"image"
+	"math"
+
+	"github.com/mum4k/termdash/canvas/braille"
+	"github.com/mum4k/termdash/cell"
+)
+
+// flatness is the maximum allowed distance (in braille sub-pixels) between
+// a quadratic Bezier curve's control point and the midpoint of its
+// endpoints before de Casteljau subdivision recurses further. One
+// sub-pixel is the finest resolution the braille canvas can plot, so
+// anything flatter than that is visually indistinguishable from the
+// curve itself.
+const flatness = 1.0
+
+// minCurveDim is the smallest width and height (in braille pixels) of a
+// bar's area for which drawing a curved slope is worthwhile, both must be
+// at least this large. Below this the polygonal
+// rasterizer is used instead, since there isn't enough room for the curve
+// to read as anything but jagged.
+const minCurveDim = 2 * slopeWidth
+
+// hasRoomForCurve reports whether ar is large enough for the Bezier
+// rasterizer to produce a visibly smoother result than the polygonal one.
+func hasRoomForCurve(ar image.Rectangle) bool {
+	return ar.Dx() >= minCurveDim && ar.Dy() >= minCurveDim
+}
+
+// floatPoint is a point with floating point coordinates, used while
+// subdividing Bezier curves so rounding to pixels only happens once, at
+// plot time.
+type floatPoint struct {
+	x, y float64
+}
+
+// quadBezier recursively subdivides the quadratic Bezier curve defined by
+// the control points p0, p1, p2 using de Casteljau's algorithm:
+//
+//	M01 = (P0+P1)/2
+//	M12 = (P1+P2)/2
+//	M   = (M01+M12)/2
+//
+// and recurses into (P0, M01, M) and (M, M12, P2) until the flatness
+// metric, max(|P1 - (P0+P2)/2|), drops to or below the flatness constant,
+// at which point the curve is approximated by the straight segment
+// (P0, P2). Returns the resulting polyline, including both endpoints.
+func quadBezier(p0, p1, p2 floatPoint) []floatPoint {
+	mx, my := (p0.x+p2.x)/2, (p0.y+p2.y)/2
+	if math.Hypot(p1.x-mx, p1.y-my) <= flatness {
+		return []floatPoint{p0, p2}
+	}
+
+	m01 := floatPoint{(p0.x + p1.x) / 2, (p0.y + p1.y) / 2}
+	m12 := floatPoint{(p1.x + p2.x) / 2, (p1.y + p2.y) / 2}
+	m := floatPoint{(m01.x + m12.x) / 2, (m01.y + m12.y) / 2}
+
+	left := quadBezier(p0, m01, m)
+	right := quadBezier(m, m12, p2)
+	// left's last point and right's first point are both m, don't repeat it.
+	return append(left[:len(left)-1], right...)
+}
+
+// hvBezier draws a bar segment whose slopes (the diagonal transitions at
+// either end, e.g. where F meets A1) are quadratic Bezier curves plotted
+// with Wu-style two-pixel coverage splitting, so that the slope of one
+// segment flows continuously into the slope of its neighbour instead of
+// stair-stepping. The flat body of the bar is still filled as a straight
+// polygon.
+func hvBezier(bc *braille.Canvas, ar image.Rectangle, st Type, o *options) error {
+	length := ar.Dx()
+	if st == Vertical {
+		length = ar.Dy()
+	}
+
+	slope := slopeWidth
+	if length <= o.skipSlopesLTE || 2*slope >= length {
+		slope = 0
+	}
+	if o.reverseSlopes {
+		slope = -slope
+	}
+
+	body, curves := hvCurveControlPoints(ar, st, slope)
+	if err := fillPolygon(bc, body, o.cellOpts); err != nil {
+		return err
+	}
+	for _, c := range curves {
+		if err := plotCurve(bc, c[0], c[1], c[2], o.cellOpts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hvCurveControlPoints returns the polygon for the flat body of the bar
+// plus the control points, (P0, P1, P2), of the two curved slopes at
+// either end.
+func hvCurveControlPoints(ar image.Rectangle, st Type, slope int) ([]image.Point, [][3]floatPoint) {
+	abs := absInt(slope)
+	if st == Horizontal {
+		y0, y1 := float64(ar.Min.Y), float64(ar.Max.Y-1)
+		yMid := (y0 + y1) / 2
+		body := image.Rect(ar.Min.X+abs, ar.Min.Y, ar.Max.X-abs, ar.Max.Y)
+		left := [3]floatPoint{
+			{float64(ar.Min.X), yMid},
+			{float64(ar.Min.X) + float64(abs)/2, y0},
+			{float64(ar.Min.X) + float64(abs), y0},
+		}
+		right := [3]floatPoint{
+			{float64(ar.Max.X - 1 - abs), y1},
+			{float64(ar.Max.X-1) - float64(abs)/2, y1},
+			{float64(ar.Max.X - 1), yMid},
+		}
+		return boundsToPoly(body), [][3]floatPoint{left, right}
+	}
+
+	x0, x1 := float64(ar.Min.X), float64(ar.Max.X-1)
+	xMid := (x0 + x1) / 2
+	body := image.Rect(ar.Min.X, ar.Min.Y+abs, ar.Max.X, ar.Max.Y-abs)
+	top := [3]floatPoint{
+		{xMid, float64(ar.Min.Y)},
+		{x1 - float64(abs)/2, float64(ar.Min.Y) + float64(abs)/2},
+		{x1, float64(ar.Min.Y + abs)},
+	}
+	bottom := [3]floatPoint{
+		{x0, float64(ar.Max.Y - 1 - abs)},
+		{x0 + float64(abs)/2, float64(ar.Max.Y-1) - float64(abs)/2},
+		{xMid, float64(ar.Max.Y - 1)},
+	}
+	return boundsToPoly(body), [][3]floatPoint{top, bottom}
+}
+
+// boundsToPoly returns the four corners of r as a polygon.
+func boundsToPoly(r image.Rectangle) []image.Point {
+	return []image.Point{
+		{r.Min.X, r.Min.Y},
+		{r.Max.X, r.Min.Y},
+		{r.Max.X, r.Max.Y},
+		{r.Min.X, r.Max.Y},
+	}
+}
+
+// plotCurve subdivides the quadratic Bezier curve (p0, p1, p2) and plots
+// the resulting polyline onto bc, splitting each point's coverage between
+// its two nearest pixels (Wu-style antialiasing) so the curve doesn't
+// alias onto a single row or column of braille dots.
+func plotCurve(bc *braille.Canvas, p0, p1, p2 floatPoint, cOpts []cell.Option) error {
+	for _, p := range quadBezier(p0, p1, p2) {
+		for _, dot := range wuSplit(p) {
+			if err := bc.SetPixel(dot, cOpts...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// wuSplit returns the one or two integer pixels that should be set to
+// represent the fractional point p, following Xiaolin Wu's approach of
+// splitting coverage between the two pixels nearest the true position:
+// the pixel p rounds down to always gets set, and its neighbour in the
+// direction of the fractional remainder also gets set whenever that
+// remainder indicates meaningful coverage (between 1/3 and 2/3), so the
+// curve doesn't alias onto a single row or column of dots.
+func wuSplit(p floatPoint) []image.Point {
+	x0, y0 := math.Floor(p.x), math.Floor(p.y)
+	fx, fy := p.x-x0, p.y-y0
+
+	pts := []image.Point{{int(x0), int(y0)}}
+	if fx >= 1.0/3 && fx <= 2.0/3 {
+		pts = append(pts, image.Point{int(x0) + 1, int(y0)})
+	}
+	if fy >= 1.0/3 && fy <= 2.0/3 {
+		pts = append(pts, image.Point{int(x0), int(y0) + 1})
+	}
+	return pts
+}
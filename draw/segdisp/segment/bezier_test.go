@@ -0,0 +1,63 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segment
+
+import (
+	"image"
+	"testing"
+)
+
+func TestHVCurveControlPointsSkipsSlope(t *testing.T) {
+	ar := image.Rect(0, 0, 10, 5)
+
+	body, _ := hvCurveControlPoints(ar, Horizontal, 0)
+	want := boundsToPoly(ar)
+	if !polyEqual(body, want) {
+		t.Errorf("hvCurveControlPoints(%v, Horizontal, 0) body => %v, want %v (slope 0 must not shrink the body)", ar, body, want)
+	}
+}
+
+func TestHVCurveControlPointsAppliesSlope(t *testing.T) {
+	ar := image.Rect(0, 0, 10, 5)
+
+	body, _ := hvCurveControlPoints(ar, Horizontal, 2)
+	want := boundsToPoly(image.Rect(2, 0, 8, 5))
+	if !polyEqual(body, want) {
+		t.Errorf("hvCurveControlPoints(%v, Horizontal, 2) body => %v, want %v", ar, body, want)
+	}
+}
+
+func polyEqual(a, b []image.Point) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestQuadBezierFlatLineReturnsEndpoints(t *testing.T) {
+	p0 := floatPoint{0, 0}
+	p1 := floatPoint{5, 0}
+	p2 := floatPoint{10, 0}
+
+	got := quadBezier(p0, p1, p2)
+	if len(got) != 2 || got[0] != p0 || got[len(got)-1] != p2 {
+		t.Errorf("quadBezier(%v, %v, %v) => %v, want a 2-point line from %v to %v", p0, p1, p2, got, p0, p2)
+	}
+}
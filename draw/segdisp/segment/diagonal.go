@@ -0,0 +1,143 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segment
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/mum4k/termdash/canvas/braille"
+	"github.com/mum4k/termdash/cell"
+)
+
+// DiagonalType identifies which of the four diagonal segments (H, K, N or
+// L) is being drawn, i.e. which corner of its surrounding area it points
+// away from.
+type DiagonalType int
+
+// String implements fmt.Stringer.
+func (dt DiagonalType) String() string {
+	switch dt {
+	case UpperLeft:
+		return "UpperLeft"
+	case UpperRight:
+		return "UpperRight"
+	case LowerLeft:
+		return "LowerLeft"
+	case LowerRight:
+		return "LowerRight"
+	default:
+		return "DiagonalTypeUnknown"
+	}
+}
+
+const (
+	diagonalTypeUnknown DiagonalType = iota
+
+	// UpperLeft identifies the H segment, slanting "\" in the upper-left quadrant.
+	UpperLeft
+	// UpperRight identifies the K segment, slanting "/" in the upper-right quadrant.
+	UpperRight
+	// LowerLeft identifies the N segment, slanting "/" in the lower-left quadrant.
+	LowerLeft
+	// LowerRight identifies the L segment, slanting "\" in the lower-right quadrant.
+	LowerRight
+)
+
+// DiagonalOption is used to provide options to Diagonal.
+type DiagonalOption interface {
+	// set sets the provided option.
+	set(*diagonalOptions)
+}
+
+// diagonalOption implements DiagonalOption.
+type diagonalOption func(*diagonalOptions)
+
+// set implements DiagonalOption.set.
+func (do diagonalOption) set(opts *diagonalOptions) {
+	do(opts)
+}
+
+// diagonalOptions stores the options provided to Diagonal.
+type diagonalOptions struct {
+	cellOpts []cell.Option
+	style    Style
+}
+
+// newDiagonalOptions returns diagonalOptions populated with the defaults.
+func newDiagonalOptions() *diagonalOptions {
+	return &diagonalOptions{
+		style: StylePolygon,
+	}
+}
+
+// DiagonalCellOpts sets the cell options on the cells that contain the
+// segment.
+func DiagonalCellOpts(cOpts ...cell.Option) DiagonalOption {
+	return diagonalOption(func(opts *diagonalOptions) {
+		opts.cellOpts = cOpts
+	})
+}
+
+// DiagonalWithStyle is accepted for symmetry with WithStyle, but currently
+// has no effect: StyleBezier only curves the slopes at the ends of HV bar
+// segments (see hvBezier), the H, K, N and L diagonals themselves are
+// always drawn as straight parallelograms.
+func DiagonalWithStyle(s Style) DiagonalOption {
+	return diagonalOption(func(opts *diagonalOptions) {
+		opts.style = s
+	})
+}
+
+// Diagonal draws a diagonal segment of the provided type within ar, a
+// braille pixel area whose size, size, is used to derive the thickness of
+// the parallelogram forming the segment.
+func Diagonal(bc *braille.Canvas, ar image.Rectangle, size image.Point, dt DiagonalType, opts ...DiagonalOption) error {
+	if ar.Dx() <= 0 || ar.Dy() <= 0 {
+		return fmt.Errorf("invalid segment area %v, both dimensions must be positive", ar)
+	}
+
+	o := newDiagonalOptions()
+	for _, opt := range opts {
+		opt.set(o)
+	}
+
+	thick := size.X / 4
+	if thick < 1 {
+		thick = 1
+	}
+
+	return fillPolygon(bc, diagonalPolygon(ar, thick, dt), o.cellOpts)
+}
+
+// diagonalPolygon returns the parallelogram vertices for a diagonal
+// segment of the provided type within ar, thick braille pixels wide.
+func diagonalPolygon(ar image.Rectangle, thick int, dt DiagonalType) []image.Point {
+	x0, y0 := ar.Min.X, ar.Min.Y
+	x1, y1 := ar.Max.X-1, ar.Max.Y-1
+
+	switch dt {
+	case UpperLeft:
+		return []image.Point{{x0, y0}, {x0 + thick, y0}, {x1, y1 - thick}, {x1, y1}}
+	case UpperRight:
+		return []image.Point{{x0, y1}, {x0, y1 - thick}, {x1 - thick, y0}, {x1, y0}}
+	case LowerLeft:
+		return []image.Point{{x0, y1}, {x0 + thick, y1}, {x1, y0 + thick}, {x1, y0}}
+	case LowerRight:
+		return []image.Point{{x0, y0}, {x0, y0 + thick}, {x1 - thick, y1}, {x1, y1}}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,40 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segment
+
+import (
+	"image"
+	"testing"
+)
+
+func TestDiagonalPolygon(t *testing.T) {
+	ar := image.Rect(0, 0, 10, 10)
+
+	tests := []struct {
+		dt   DiagonalType
+		want []image.Point
+	}{
+		{UpperLeft, []image.Point{{0, 0}, {2, 0}, {9, 7}, {9, 9}}},
+		{UpperRight, []image.Point{{0, 9}, {0, 7}, {7, 0}, {9, 0}}},
+		{LowerLeft, []image.Point{{0, 9}, {2, 9}, {9, 2}, {9, 0}}},
+		{LowerRight, []image.Point{{0, 0}, {0, 2}, {7, 9}, {9, 9}}},
+	}
+	for _, tc := range tests {
+		got := diagonalPolygon(ar, 2, tc.dt)
+		if !polyEqual(got, tc.want) {
+			t.Errorf("diagonalPolygon(%v, 2, %v) => %v, want %v", ar, tc.dt, got, tc.want)
+		}
+	}
+}
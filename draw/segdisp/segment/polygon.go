@@ -0,0 +1,113 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package segment
+
+import (
+	"image"
+
+	"github.com/mum4k/termdash/canvas/braille"
+	"github.com/mum4k/termdash/cell"
+)
+
+// slopeWidth is how many braille columns (for a Horizontal bar) or rows
+// (for a Vertical bar) the slope at either end of the bar consumes.
+const slopeWidth = 2
+
+// hvPolygon draws a bar segment as a straight-edged hexagon: a rectangular
+// body with a pointed slope at either end, so that adjoining bars (e.g.
+// F and A1) meet at a shared diagonal edge instead of a right angle.
+func hvPolygon(bc *braille.Canvas, ar image.Rectangle, st Type, o *options) error {
+	length := ar.Dx()
+	if st == Vertical {
+		length = ar.Dy()
+	}
+
+	slope := slopeWidth
+	if length <= o.skipSlopesLTE || 2*slope >= length {
+		slope = 0
+	}
+	if o.reverseSlopes {
+		slope = -slope
+	}
+
+	poly := hexagon(ar, st, slope)
+	return fillPolygon(bc, poly, o.cellOpts)
+}
+
+// hexagon returns the vertices of the bar's outline, in order, for the
+// provided area, orientation and slope width. Only the magnitude of slope
+// is used; a negative slope yields the same outline as a positive one.
+func hexagon(ar image.Rectangle, st Type, slope int) []image.Point {
+	if st == Horizontal {
+		y0, y1 := ar.Min.Y, ar.Max.Y-1
+		return []image.Point{
+			{ar.Min.X, (y0 + y1) / 2},
+			{ar.Min.X + absInt(slope), y0},
+			{ar.Max.X - 1 - absInt(slope), y0},
+			{ar.Max.X - 1, (y0 + y1) / 2},
+			{ar.Max.X - 1 - absInt(slope), y1},
+			{ar.Min.X + absInt(slope), y1},
+		}
+	}
+	x0, x1 := ar.Min.X, ar.Max.X-1
+	return []image.Point{
+		{(x0 + x1) / 2, ar.Min.Y},
+		{x1, ar.Min.Y + absInt(slope)},
+		{x1, ar.Max.Y - 1 - absInt(slope)},
+		{(x0 + x1) / 2, ar.Max.Y - 1},
+		{x0, ar.Max.Y - 1 - absInt(slope)},
+		{x0, ar.Min.Y + absInt(slope)},
+	}
+}
+
+// fillPolygon sets every braille pixel within bc's area that falls inside
+// the polygon described by poly (vertices in order).
+func fillPolygon(bc *braille.Canvas, poly []image.Point, cOpts []cell.Option) error {
+	ar := bc.Area()
+	for y := ar.Min.Y; y < ar.Max.Y; y++ {
+		for x := ar.Min.X; x < ar.Max.X; x++ {
+			p := image.Point{x, y}
+			if !pointInPolygon(p, poly) {
+				continue
+			}
+			if err := bc.SetPixel(p, cOpts...); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// pointInPolygon reports whether p falls within the polygon described by
+// the provided vertices, using the standard ray casting algorithm.
+func pointInPolygon(p image.Point, poly []image.Point) bool {
+	inside := false
+	for i, j := 0, len(poly)-1; i < len(poly); j, i = i, i+1 {
+		pi, pj := poly[i], poly[j]
+		if (pi.Y > p.Y) != (pj.Y > p.Y) &&
+			p.X < (pj.X-pi.X)*(p.Y-pi.Y)/(pj.Y-pi.Y)+pi.X {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// absInt returns the absolute value of n.
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,161 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package segment draws the individual segments of a segment display (e.g.
+// sixteen.Display) onto a braille canvas.
+package segment
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/mum4k/termdash/canvas/braille"
+	"github.com/mum4k/termdash/cell"
+)
+
+// Type identifies the orientation of a bar segment drawn by HV.
+type Type int
+
+// String implements fmt.Stringer.
+func (t Type) String() string {
+	switch t {
+	case Horizontal:
+		return "Horizontal"
+	case Vertical:
+		return "Vertical"
+	default:
+		return "TypeUnknown"
+	}
+}
+
+const (
+	typeUnknown Type = iota
+
+	// Horizontal identifies a horizontal bar segment, e.g. A1 or G2.
+	Horizontal
+	// Vertical identifies a vertical bar segment, e.g. F or J.
+	Vertical
+)
+
+// Style identifies the rasterizer used to draw a segment's outline.
+type Style int
+
+// String implements fmt.Stringer.
+func (s Style) String() string {
+	switch s {
+	case StylePolygon:
+		return "StylePolygon"
+	case StyleBezier:
+		return "StyleBezier"
+	default:
+		return "StyleUnknown"
+	}
+}
+
+const (
+	styleUnknown Style = iota
+
+	// StylePolygon draws segments as straight-edged polygons. This is the
+	// default and is always used as a fallback when the target area is
+	// too small for meaningful curvature.
+	StylePolygon
+	// StyleBezier draws the slopes at the ends of a bar segment (the
+	// transitions between adjoining segments, e.g. F→A1 or A2→B) as
+	// antialiased quadratic Bezier curves instead of straight diagonals.
+	StyleBezier
+)
+
+// Option is used to provide options to HV.
+type Option interface {
+	// set sets the provided option.
+	set(*options)
+}
+
+// option implements Option.
+type option func(*options)
+
+// set implements Option.set.
+func (o option) set(opts *options) {
+	o(opts)
+}
+
+// options stores the options provided to HV.
+type options struct {
+	cellOpts      []cell.Option
+	skipSlopesLTE int
+	reverseSlopes bool
+	style         Style
+}
+
+// newOptions returns options populated with the defaults.
+func newOptions() *options {
+	return &options{
+		style: StylePolygon,
+	}
+}
+
+// CellOpts sets the cell options on the cells that contain the segment.
+func CellOpts(cOpts ...cell.Option) Option {
+	return option(func(opts *options) {
+		opts.cellOpts = cOpts
+	})
+}
+
+// SkipSlopesLTE skips drawing the slope at either end of the bar if the
+// bar's length (in braille columns or rows, depending on its Type) is less
+// than or equal to max. Short bars have no room to draw a meaningful
+// slope.
+func SkipSlopesLTE(max int) Option {
+	return option(func(opts *options) {
+		opts.skipSlopesLTE = max
+	})
+}
+
+// ReverseSlopes negates the slope width passed to the outline builders
+// (hexagon and hvCurveControlPoints). Both use only the slope's magnitude,
+// so a bar is drawn the same with or without this option.
+func ReverseSlopes() Option {
+	return option(func(opts *options) {
+		opts.reverseSlopes = true
+	})
+}
+
+// WithStyle selects the rasterizer used to draw the segment's outline.
+// Defaults to StylePolygon.
+func WithStyle(s Style) Option {
+	return option(func(opts *options) {
+		opts.style = s
+	})
+}
+
+// HV draws a horizontal or vertical bar segment of type st within the
+// provided braille pixel area.
+func HV(bc *braille.Canvas, ar image.Rectangle, st Type, opts ...Option) error {
+	if ar.Dx() <= 0 || ar.Dy() <= 0 {
+		return fmt.Errorf("invalid segment area %v, both dimensions must be positive", ar)
+	}
+	if st != Horizontal && st != Vertical {
+		return fmt.Errorf("invalid segment type %v", st)
+	}
+
+	o := newOptions()
+	for _, opt := range opts {
+		opt.set(o)
+	}
+
+	if o.style == StyleBezier && hasRoomForCurve(ar) {
+		return hvBezier(bc, ar, st, o)
+	}
+	return hvPolygon(bc, ar, st, o)
+}
@@ -0,0 +1,111 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sixteen
+
+import (
+	"fmt"
+	"sync"
+)
+
+// characterSegmentsMu guards characterSegments. Unlike builtinCharacterSegments,
+// which is immutable after init, characterSegments can be mutated at
+// runtime by RegisterCharacter and UnregisterCharacter while SetCharacter,
+// SupportsChars and Sanitize read it concurrently, e.g. from a widget's
+// redraw goroutine.
+var characterSegmentsMu sync.RWMutex
+
+// characterSegments maps characters that can be displayed to their
+// segments. Starts as a copy of builtinCharacterSegments and can be
+// extended or overridden at runtime via RegisterCharacter and
+// UnregisterCharacter. Access must hold characterSegmentsMu.
+var characterSegments = map[rune][]Segment{}
+
+func init() {
+	for r, segs := range builtinCharacterSegments {
+		characterSegments[r] = segs
+	}
+}
+
+// RegisterOption is used to provide options to RegisterCharacter.
+type RegisterOption interface {
+	// set sets the provided option.
+	set(*registerOptions)
+}
+
+// registerOption implements RegisterOption.
+type registerOption func(*registerOptions)
+
+// set implements RegisterOption.set.
+func (ro registerOption) set(opts *registerOptions) {
+	ro(opts)
+}
+
+// registerOptions stores the options provided to RegisterCharacter.
+type registerOptions struct {
+	override bool
+}
+
+// Override allows RegisterCharacter to replace a character that is already
+// mapped, whether built-in or previously registered. Without this option,
+// RegisterCharacter refuses to replace an existing mapping.
+func Override() RegisterOption {
+	return registerOption(func(opts *registerOptions) {
+		opts.override = true
+	})
+}
+
+// RegisterCharacter teaches the display how to render the rune r by
+// turning on the provided segments. This allows callers to extend the
+// built-in ASCII-only character map, e.g. with Latin-1 glyphs, katakana
+// approximations or domain-specific icons.
+// Returns an error if any of the segments is invalid, or if r is already
+// mapped and Override() wasn't provided.
+func RegisterCharacter(r rune, segs []Segment, opts ...RegisterOption) error {
+	o := &registerOptions{}
+	for _, opt := range opts {
+		opt.set(o)
+	}
+
+	for _, s := range segs {
+		if s <= segmentUnknown || s >= segmentMax {
+			return fmt.Errorf("invalid segment %v(%d) in the character map for %q", s, s, r)
+		}
+	}
+
+	characterSegmentsMu.Lock()
+	defer characterSegmentsMu.Unlock()
+
+	if _, ok := characterSegments[r]; ok && !o.override {
+		return fmt.Errorf("character %q is already mapped, provide the Override() option to replace it", r)
+	}
+
+	characterSegments[r] = segs
+	return nil
+}
+
+// UnregisterCharacter removes a previously registered mapping for the rune
+// r. If r is one of the built-in characters, this reverts it back to the
+// built-in segments rather than making it unsupported.
+// This method is idempotent.
+func UnregisterCharacter(r rune) {
+	characterSegmentsMu.Lock()
+	defer characterSegmentsMu.Unlock()
+
+	if segs, ok := builtinCharacterSegments[r]; ok {
+		characterSegments[r] = segs
+		return
+	}
+	delete(characterSegments, r)
+}
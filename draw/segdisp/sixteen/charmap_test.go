@@ -0,0 +1,48 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sixteen
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestCharacterSegmentsConcurrentAccess exercises RegisterCharacter,
+// UnregisterCharacter and the read paths (SetCharacter, SupportsChars,
+// Sanitize) concurrently. Run with -race to catch regressions of the
+// "concurrent map read and map write" hazard this test guards against.
+func TestCharacterSegmentsConcurrentAccess(t *testing.T) {
+	const workers = 8
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if err := RegisterCharacter('☃', []Segment{A1, D1}, Override()); err != nil {
+					t.Error(err)
+				}
+				UnregisterCharacter('☃')
+
+				d := New()
+				_ = d.SetCharacter('A')
+				SupportsChars("A snowman? ☃")
+				Sanitize("A snowman? ☃")
+			}
+		}()
+	}
+	wg.Wait()
+}
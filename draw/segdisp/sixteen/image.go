@@ -0,0 +1,164 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sixteen
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+)
+
+// ImageOption is used to provide options to Display.RenderImage.
+type ImageOption interface {
+	// set sets the provided option.
+	set(*imageOptions)
+}
+
+// imageOption implements ImageOption.
+type imageOption func(*imageOptions)
+
+// set implements ImageOption.set.
+func (io imageOption) set(opts *imageOptions) {
+	io(opts)
+}
+
+// imageOptions stores the options provided to Display.RenderImage.
+type imageOptions struct {
+	onColor  color.Color
+	offColor color.Color
+	bgColor  color.Color
+}
+
+// newImageOptions returns imageOptions populated with the default colors.
+func newImageOptions() *imageOptions {
+	return &imageOptions{
+		onColor:  color.RGBA{0xff, 0x00, 0x00, 0xff},
+		offColor: color.RGBA{0x28, 0x28, 0x28, 0xff},
+		bgColor:  color.Black,
+	}
+}
+
+// ImageOnColor sets the color used to paint segments that are switched on.
+// Defaults to red.
+func ImageOnColor(c color.Color) ImageOption {
+	return imageOption(func(opts *imageOptions) {
+		opts.onColor = c
+	})
+}
+
+// ImageOffColor sets the color used to paint segments that are switched
+// off. Defaults to a dark gray.
+func ImageOffColor(c color.Color) ImageOption {
+	return imageOption(func(opts *imageOptions) {
+		opts.offColor = c
+	})
+}
+
+// ImageBackgroundColor sets the color painted into the pixels that aren't
+// covered by any segment. Defaults to black.
+func ImageBackgroundColor(c color.Color) ImageOption {
+	return imageOption(func(opts *imageOptions) {
+		opts.bgColor = c
+	})
+}
+
+// RenderImage rasterizes the current state of the display into an
+// *image.RGBA of the given pixel size, independent of any canvas or
+// terminal. Segments are drawn as filled polygons (hexagons for the
+// horizontal and vertical bars, parallelograms for the diagonals), so the
+// result can be encoded (e.g. via segdisp.EncodeImage) and used outside of
+// a terminal, for example in a dashboard exported to a file or served over
+// HTTP.
+func (d *Display) RenderImage(size image.Point, opts ...ImageOption) (image.Image, error) {
+	if size.X <= 0 || size.Y <= 0 {
+		return nil, fmt.Errorf("invalid image size %v, both dimensions must be positive", size)
+	}
+
+	o := newImageOptions()
+	for _, opt := range opts {
+		opt.set(o)
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, size.X, size.Y))
+	draw.Draw(img, img.Bounds(), image.NewUniform(o.bgColor), image.ZP, draw.Src)
+
+	g := newImgGeometry(size)
+	for _, s := range hvImgSegments {
+		fillPolygon(img, g.hvPolygon(s), d.imgColor(s, o))
+	}
+	for _, s := range diaImgSegments {
+		fillPolygon(img, g.diaPolygon(s), d.imgColor(s, o))
+	}
+	return img, nil
+}
+
+// imgColor returns the color that segment s should be painted with.
+func (d *Display) imgColor(s Segment, o *imageOptions) color.Color {
+	if d.segments[s] {
+		return o.onColor
+	}
+	return o.offColor
+}
+
+// fillPolygon paints all pixels of img that fall within the polygon
+// described by the provided vertices (in order) with color c.
+// Uses a scanline point-in-polygon test, which is sufficient for the convex
+// hexagons and parallelograms used to draw the segments.
+func fillPolygon(img *image.RGBA, poly []image.Point, c color.Color) {
+	if len(poly) == 0 {
+		return
+	}
+
+	minY, maxY := poly[0].Y, poly[0].Y
+	for _, p := range poly {
+		if p.Y < minY {
+			minY = p.Y
+		}
+		if p.Y > maxY {
+			maxY = p.Y
+		}
+	}
+
+	b := img.Bounds()
+	if minY < b.Min.Y {
+		minY = b.Min.Y
+	}
+	if maxY > b.Max.Y-1 {
+		maxY = b.Max.Y - 1
+	}
+
+	for y := minY; y <= maxY; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if pointInPolygon(image.Point{x, y}, poly) {
+				img.Set(x, y, c)
+			}
+		}
+	}
+}
+
+// pointInPolygon reports whether p falls within the polygon described by
+// the provided vertices, using the standard ray casting algorithm.
+func pointInPolygon(p image.Point, poly []image.Point) bool {
+	inside := false
+	for i, j := 0, len(poly)-1; i < len(poly); j, i = i, i+1 {
+		pi, pj := poly[i], poly[j]
+		if (pi.Y > p.Y) != (pj.Y > p.Y) &&
+			p.X < (pj.X-pi.X)*(p.Y-pi.Y)/(pj.Y-pi.Y)+pi.X {
+			inside = !inside
+		}
+	}
+	return inside
+}
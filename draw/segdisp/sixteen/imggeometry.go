@@ -0,0 +1,169 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sixteen
+
+import "image"
+
+// hvImgSegments are the segments drawn as hexagon bars, i.e. the segments
+// passed to segment.HV when drawing onto a braille canvas.
+var hvImgSegments = []Segment{A1, A2, F, J, B, G1, G2, E, M, C, D1, D2}
+
+// diaImgSegments are the segments drawn as parallelograms, i.e. the
+// segments passed to segment.Diagonal when drawing onto a braille canvas.
+var diaImgSegments = []Segment{H, K, N, L}
+
+// imgGeometry holds the pixel coordinates of the landmark points of the
+// display, laid out as in the package level diagram, and is used to
+// compute the polygon for each individual segment in RenderImage.
+type imgGeometry struct {
+	// x0, x1, x2, x3 and x4 are, from left to right, the x coordinates of
+	// the left edge, the left inner column (where F, E and the diagonals
+	// meet), the center column (where J and M sit), the right inner
+	// column and the right edge.
+	x0, x1, x2, x3, x4 int
+
+	// y0, y1, y2, y3 and y4 are, from top to bottom, the y coordinates of
+	// the top edge, the top inner row, the middle row (where G1 and G2
+	// sit), the bottom inner row and the bottom edge.
+	y0, y1, y2, y3, y4 int
+
+	// barThick is the thickness in pixels of a horizontal or vertical bar
+	// segment.
+	barThick int
+}
+
+// newImgGeometry derives the landmark points of the display for an image of
+// the provided pixel size.
+func newImgGeometry(size image.Point) *imgGeometry {
+	g := &imgGeometry{
+		x0: 0,
+		x4: size.X - 1,
+		y0: 0,
+		y4: size.Y - 1,
+	}
+	g.x2 = size.X / 2
+	g.y2 = size.Y / 2
+
+	g.x1 = size.X / 6
+	g.x3 = size.X - g.x1
+
+	g.y1 = size.Y / 6
+	g.y3 = size.Y - g.y1
+
+	g.barThick = size.X / 10
+	if g.barThick < 1 {
+		g.barThick = 1
+	}
+	return g
+}
+
+// hvPolygon returns the hexagon vertices for one of the hvImgSegments.
+func (g *imgGeometry) hvPolygon(s Segment) []image.Point {
+	t := g.barThick / 2
+
+	switch s {
+	case A1:
+		return g.hBar(g.x0, g.x2, g.y0, t)
+	case A2:
+		return g.hBar(g.x2, g.x4, g.y0, t)
+	case D1:
+		return g.hBar(g.x0, g.x2, g.y4, t)
+	case D2:
+		return g.hBar(g.x2, g.x4, g.y4, t)
+	case G1:
+		return g.hBar(g.x0, g.x2, g.y2, t)
+	case G2:
+		return g.hBar(g.x2, g.x4, g.y2, t)
+
+	case F:
+		return g.vBar(g.x0, g.y0, g.y2, t)
+	case B:
+		return g.vBar(g.x4, g.y0, g.y2, t)
+	case E:
+		return g.vBar(g.x0, g.y2, g.y4, t)
+	case C:
+		return g.vBar(g.x4, g.y2, g.y4, t)
+	case J:
+		return g.vBar(g.x2, g.y0, g.y2, t)
+	case M:
+		return g.vBar(g.x2, g.y2, g.y4, t)
+	}
+	return nil
+}
+
+// diaPolygon returns the parallelogram vertices for one of the
+// diaImgSegments.
+func (g *imgGeometry) diaPolygon(s Segment) []image.Point {
+	t := g.barThick / 2
+
+	switch s {
+	case H: // Upper-left diagonal, between F, A1 and J.
+		return []image.Point{
+			{g.x0 + t, g.y0},
+			{g.x0 + 2*t, g.y0},
+			{g.x2, g.y2 - t},
+			{g.x2, g.y2 + t},
+		}
+	case K: // Upper-right diagonal, between J, A2 and B.
+		return []image.Point{
+			{g.x4 - 2*t, g.y0},
+			{g.x4 - t, g.y0},
+			{g.x2, g.y2 + t},
+			{g.x2, g.y2 - t},
+		}
+	case N: // Lower-left diagonal, between E, G1 and M.
+		return []image.Point{
+			{g.x2, g.y2 - t},
+			{g.x2, g.y2 + t},
+			{g.x0 + 2*t, g.y4},
+			{g.x0 + t, g.y4},
+		}
+	case L: // Lower-right diagonal, between M, G2 and C.
+		return []image.Point{
+			{g.x2, g.y2 + t},
+			{g.x2, g.y2 - t},
+			{g.x4 - t, g.y4},
+			{g.x4 - 2*t, g.y4},
+		}
+	}
+	return nil
+}
+
+// hBar returns the vertices of a horizontal hexagon bar spanning from
+// fromX to toX, vertically centered on y with a half-thickness of t.
+// The pointed ends make adjoining bars meet cleanly at shared corners.
+func (g *imgGeometry) hBar(fromX, toX, y, t int) []image.Point {
+	return []image.Point{
+		{fromX, y},
+		{fromX + t, y - t},
+		{toX - t, y - t},
+		{toX, y},
+		{toX - t, y + t},
+		{fromX + t, y + t},
+	}
+}
+
+// vBar returns the vertices of a vertical hexagon bar spanning from fromY
+// to toY, horizontally centered on x with a half-thickness of t.
+func (g *imgGeometry) vBar(x, fromY, toY, t int) []image.Point {
+	return []image.Point{
+		{x, fromY},
+		{x + t, fromY + t},
+		{x + t, toY - t},
+		{x, toY},
+		{x - t, toY - t},
+		{x - t, fromY + t},
+	}
+}
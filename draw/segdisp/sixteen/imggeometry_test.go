@@ -0,0 +1,53 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sixteen
+
+import (
+	"image"
+	"reflect"
+	"testing"
+)
+
+func TestImgGeometryHBar(t *testing.T) {
+	g := &imgGeometry{}
+	got := g.hBar(0, 30, 100, 3)
+	want := []image.Point{
+		{0, 100},
+		{3, 97},
+		{27, 97},
+		{30, 100},
+		{27, 103},
+		{3, 103},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("hBar(0, 30, 100, 3) => %v, want %v", got, want)
+	}
+}
+
+func TestImgGeometryVBar(t *testing.T) {
+	g := &imgGeometry{}
+	got := g.vBar(100, 0, 30, 3)
+	want := []image.Point{
+		{100, 0},
+		{103, 3},
+		{103, 27},
+		{100, 30},
+		{97, 27},
+		{97, 3},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("vBar(100, 0, 30, 3) => %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright 2019 Google Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sixteen
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/mum4k/termdash/area"
+	"github.com/mum4k/termdash/canvas"
+	"github.com/mum4k/termdash/cell"
+)
+
+// Align identifies the horizontal alignment of a glyph within the cell
+// slot SetString allotted to it.
+type Align int
+
+// String implements fmt.Stringer.
+func (a Align) String() string {
+	if n, ok := alignNames[a]; ok {
+		return n
+	}
+	return "AlignUnknown"
+}
+
+// alignNames maps Align values to human readable names.
+var alignNames = map[Align]string{
+	AlignLeft:   "AlignLeft",
+	AlignCenter: "AlignCenter",
+	AlignRight:  "AlignRight",
+}
+
+const (
+	alignUnknown Align = iota
+
+	// AlignLeft aligns the glyph to the left edge of its cell slot.
+	AlignLeft
+	// AlignCenter centers the glyph within its cell slot.
+	AlignCenter
+	// AlignRight aligns the glyph to the right edge of its cell slot.
+	AlignRight
+)
+
+// slotAspectRatio is the aspect ratio a cell slot is reduced to before
+// Required is consulted, so that leftover cell columns (from dividing the
+// canvas into one slot per rune) are available for alignment rather than being
+// silently swallowed by Required's own aspect ratio adjustment.
+var slotAspectRatio = image.Point{MinCols, MinRows}
+
+// StringOption is used to provide options to SetString.
+type StringOption interface {
+	// set sets the provided option.
+	set(*stringOptions)
+}
+
+// stringOption implements StringOption.
+type stringOption func(*stringOptions)
+
+// set implements StringOption.set.
+func (so stringOption) set(opts *stringOptions) {
+	so(opts)
+}
+
+// stringOptions stores the options provided to SetString.
+type stringOptions struct {
+	align    Align
+	cellOpts []cell.Option
+	perGlyph map[int][]Option
+}
+
+// newStringOptions returns stringOptions populated with the defaults.
+func newStringOptions() *stringOptions {
+	return &stringOptions{
+		align:    AlignLeft,
+		perGlyph: map[int][]Option{},
+	}
+}
+
+// StringAlign sets the alignment of each glyph within the cell slot
+// SetString allotted to it. Defaults to AlignLeft.
+func StringAlign(a Align) StringOption {
+	return stringOption(func(opts *stringOptions) {
+		opts.align = a
+	})
+}
+
+// StringCellOpts sets the cell options applied to every glyph drawn by
+// SetString, unless overridden for an individual glyph via GlyphOptions.
+func StringCellOpts(cOpts ...cell.Option) StringOption {
+	return stringOption(func(opts *stringOptions) {
+		opts.cellOpts = cOpts
+	})
+}
+
+// GlyphOptions sets the Display options used only for the glyph at the
+// provided zero-based index into the string passed to SetString.
+// These take precedence over StringCellOpts for that glyph.
+func GlyphOptions(idx int, dispOpts ...Option) StringOption {
+	return stringOption(func(opts *stringOptions) {
+		opts.perGlyph[idx] = dispOpts
+	})
+}
+
+// SetString draws the provided string across the canvas, laying out one
+// Display per glyph. The canvas is split into one equally sized cell-slot
+// per rune of s (the last slot absorbs any leftover columns), each glyph is
+// positioned within its slot according to the provided Align, and the
+// glyph is drawn at the largest size Required allows within that slot.
+// Slot widths are fixed and independent of the glyph drawn in them, so
+// SetString doesn't kern, i.e. it never narrows a slot to reclaim the
+// whitespace either side of a glyph like '.' or '1'. Every slot is exactly
+// full.Dx()/len([]rune(s)) cells wide (plus leftover on the last one); use
+// StringAlign or GlyphOptions if the resulting spacing needs adjusting.
+// Returns the rectangle of cells actually used, which might be smaller
+// than the full canvas area.
+// Returns an error if the canvas doesn't have enough columns to give every
+// glyph at least MinCols, or if s contains an unsupported character, see
+// SupportsChars and Sanitize.
+func SetString(s string, cvs *canvas.Canvas, opts ...StringOption) (image.Rectangle, error) {
+	o := newStringOptions()
+	for _, opt := range opts {
+		opt.set(o)
+	}
+
+	runes := []rune(s)
+	if len(runes) == 0 {
+		return image.ZR, nil
+	}
+
+	full := cvs.Area()
+	slotW := full.Dx() / len(runes)
+	if slotW < MinCols {
+		return image.ZR, fmt.Errorf("canvas %v is too small to typeset %d glyph(s), each needs at least %d columns, got %d per glyph", full, len(runes), MinCols, slotW)
+	}
+
+	var used image.Rectangle
+	for i, r := range runes {
+		w := slotW
+		if i == len(runes)-1 {
+			// The last slot absorbs the leftover from the integer division
+			// above so the whole canvas width is accounted for.
+			w = full.Max.X - (full.Min.X + i*slotW)
+		}
+		slot := image.Rect(full.Min.X+i*slotW, full.Min.Y, full.Min.X+i*slotW+w, full.Max.Y)
+		ratioSlot := area.WithRatio(slot, slotAspectRatio)
+
+		glyphAr, err := Required(ratioSlot)
+		if err != nil {
+			return image.ZR, fmt.Errorf("glyph %d (%q): Required => %v", i, r, err)
+		}
+		glyphAr = alignGlyph(glyphAr, slot, o.align)
+
+		sub, err := cvs.Subcanvas(glyphAr)
+		if err != nil {
+			return image.ZR, fmt.Errorf("glyph %d (%q): cvs.Subcanvas => %v", i, r, err)
+		}
+
+		dispOpts, ok := o.perGlyph[i]
+		if !ok && len(o.cellOpts) > 0 {
+			dispOpts = []Option{CellOpts(o.cellOpts...)}
+		}
+		d := New(dispOpts...)
+		if err := d.SetCharacter(r); err != nil {
+			return image.ZR, fmt.Errorf("glyph %d: SetCharacter(%q) => %v", i, r, err)
+		}
+		if err := d.Draw(sub); err != nil {
+			return image.ZR, fmt.Errorf("glyph %d (%q): Draw => %v", i, r, err)
+		}
+
+		if used == image.ZR {
+			used = glyphAr
+		} else {
+			used = used.Union(glyphAr)
+		}
+	}
+	return used, nil
+}
+
+// alignGlyph repositions ar, which must fit within slot, according to a.
+func alignGlyph(ar, slot image.Rectangle, a Align) image.Rectangle {
+	var dx int
+	switch a {
+	case AlignCenter:
+		dx = (slot.Dx() - ar.Dx()) / 2
+	case AlignRight:
+		dx = slot.Dx() - ar.Dx()
+	default: // AlignLeft.
+		dx = 0
+	}
+	return image.Rect(slot.Min.X+dx, ar.Min.Y, slot.Min.X+dx+ar.Dx(), ar.Max.Y)
+}
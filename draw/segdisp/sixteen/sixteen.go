@@ -106,10 +106,11 @@ const (
 	segmentMax // Used for validation.
 )
 
-// characterSegments maps characters that can be displayed on their segments.
+// builtinCharacterSegments maps characters that can be displayed on their
+// segments.
 // See doc/16-Segment-ASCII-All.jpg and:
 // https://www.partsnotincluded.com/electronics/segmented-led-display-ascii-library
-var characterSegments = map[rune][]Segment{
+var builtinCharacterSegments = map[rune][]Segment{
 	' ':  nil,
 	'!':  {B, C},
 	'"':  {J, B},
@@ -217,6 +218,9 @@ var characterSegments = map[rune][]Segment{
 // The display only supports a subset of ASCII characters.
 // Returns any unsupported runes found in the string in an unspecified order.
 func SupportsChars(s string) (bool, []rune) {
+	characterSegmentsMu.RLock()
+	defer characterSegmentsMu.RUnlock()
+
 	unsupp := map[rune]bool{}
 	for _, r := range s {
 		if _, ok := characterSegments[r]; !ok {
@@ -234,6 +238,9 @@ func SupportsChars(s string) (bool, []rune) {
 // Sanitize returns a copy of the string, replacing all unsupported characters
 // with a space character.
 func Sanitize(s string) string {
+	characterSegmentsMu.RLock()
+	defer characterSegmentsMu.RUnlock()
+
 	var b bytes.Buffer
 	for _, r := range s {
 		if _, ok := characterSegments[r]; !ok {
@@ -275,6 +282,15 @@ func CellOpts(cOpts ...cell.Option) Option {
 	})
 }
 
+// Style selects the rasterizer used to draw the individual segments.
+// Defaults to segment.StylePolygon. Use segment.StyleBezier for
+// antialiased, curved segment outlines.
+func Style(s segment.Style) Option {
+	return option(func(d *Display) {
+		d.style = s
+	})
+}
+
 // Display represents the segment display.
 // This object is not thread-safe.
 type Display struct {
@@ -282,6 +298,7 @@ type Display struct {
 	segments map[Segment]bool
 
 	cellOpts []cell.Option
+	style    segment.Style
 }
 
 // New creates a new segment display.
@@ -289,6 +306,7 @@ type Display struct {
 func New(opts ...Option) *Display {
 	d := &Display{
 		segments: map[Segment]bool{},
+		style:    segment.StylePolygon,
 	}
 
 	for _, opt := range opts {
@@ -345,7 +363,9 @@ func (d *Display) ToggleSegment(s Segment) error {
 // or Sanitize() to ensure the provided character is supported.
 // Doesn't clear the display of segments set previously.
 func (d *Display) SetCharacter(c rune) error {
+	characterSegmentsMu.RLock()
 	seg, ok := characterSegments[c]
+	characterSegmentsMu.RUnlock()
 	if !ok {
 		return fmt.Errorf("display doesn't support character %q rune(%v)", c, c)
 	}
@@ -384,7 +404,7 @@ func (d *Display) Draw(cvs *canvas.Canvas, opts ...Option) error {
 	}
 
 	attr := newAttributes(bcAr)
-	var sOpts []segment.Option
+	sOpts := []segment.Option{segment.WithStyle(d.style)}
 	if len(d.cellOpts) > 0 {
 		sOpts = append(sOpts, segment.CellOpts(d.cellOpts...))
 	}
@@ -419,7 +439,7 @@ func (d *Display) Draw(cvs *canvas.Canvas, opts ...Option) error {
 		}
 	}
 
-	var dsOpts []segment.DiagonalOption
+	dsOpts := []segment.DiagonalOption{segment.DiagonalWithStyle(d.style)}
 	if len(d.cellOpts) > 0 {
 		dsOpts = append(dsOpts, segment.DiagonalCellOpts(d.cellOpts...))
 	}